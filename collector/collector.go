@@ -0,0 +1,156 @@
+// Package collector implements one prometheus.Collector per Hadoop role
+// (NameNode, DataNode, ResourceManager, ...), each scraping a single JMX
+// endpoint and translating the beans it cares about into metrics.
+//
+// Collectors register themselves from an init() in their own file, the same
+// pattern node_exporter uses for its per-subsystem collectors, so adding a
+// new role never requires touching this file.
+package collector
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/log"
+)
+
+// Factory builds a prometheus.Collector that scrapes the given role's JMX
+// endpoint at target.
+type Factory func(target string) prometheus.Collector
+
+var (
+	factories      = make(map[string]Factory)
+	collectorState = make(map[string]*bool)
+)
+
+// RegisterCollector makes a role's collector available under
+// /probe?role=name&target=..., and adds a --collector.name flag to enable or
+// disable it. Calling it again for a role that is already registered (e.g. a
+// --config.file rule set overriding a built-in role) replaces that role's
+// factory without redefining its --collector.<role> flag, which the built-in
+// collector already defined from init() by the time main loads a
+// --config.file (still before flag.Parse() runs).
+func RegisterCollector(role string, isDefaultEnabled bool, factory Factory) {
+	if _, registered := collectorState[role]; !registered {
+		flagName := fmt.Sprintf("collector.%s", role)
+		flagHelp := fmt.Sprintf("Enable the %s collector.", role)
+		collectorState[role] = flag.Bool(flagName, isDefaultEnabled, flagHelp)
+	}
+	factories[role] = factory
+}
+
+// Enabled returns the role -> Factory map for collectors whose
+// --collector.<role> flag is currently set.
+func Enabled() map[string]Factory {
+	enabled := make(map[string]Factory)
+	for role, factory := range factories {
+		if *collectorState[role] {
+			enabled[role] = factory
+		}
+	}
+	return enabled
+}
+
+// typedDesc pairs a *prometheus.Desc with the value type it should be
+// reported as, so a Collect loop can build a prometheus.Metric in one call
+// regardless of how many label values the Desc takes.
+type typedDesc struct {
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+}
+
+func (d typedDesc) mustNewConstMetric(value float64, labelValues ...string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(d.desc, d.valueType, value, labelValues...)
+}
+
+var jmxTimeout = flag.Duration("jmx.timeout", 10*time.Second, "Timeout for scraping a role's JMX endpoint.")
+
+var (
+	scrapeSuccessDesc = prometheus.NewDesc(
+		"hadoop_exporter_scrape_success",
+		"Whether the named JMX bean was present in the last scrape.",
+		[]string{"bean"}, nil,
+	)
+	scrapeDurationDesc = prometheus.NewDesc(
+		"hadoop_exporter_scrape_duration_seconds",
+		"Time the JMX scrape took, in seconds.",
+		nil, nil,
+	)
+	lastScrapeErrorDesc = prometheus.NewDesc(
+		"hadoop_exporter_last_scrape_error",
+		"1 if the JMX endpoint could not be scraped or decoded, 0 otherwise.",
+		nil, nil,
+	)
+)
+
+// fetchJMXBeans scrapes url's JMX bean list under --jmx.timeout, emits the
+// hadoop_exporter self-metrics onto ch, and returns the decoded beans. It
+// never panics on a slow, dead, or malformed target: on any failure it
+// returns a nil slice and hadoop_exporter_last_scrape_error is set to 1.
+func fetchJMXBeans(ch chan<- prometheus.Metric, url string) []interface{} {
+	start := time.Now()
+	client := &http.Client{Timeout: *jmxTimeout}
+
+	var beans []interface{}
+	scrapeError := 0.0
+
+	resp, err := client.Get(url)
+	if err != nil {
+		log.Error(err)
+		scrapeError = 1.0
+	} else {
+		defer resp.Body.Close()
+		data, readErr := ioutil.ReadAll(resp.Body)
+		if readErr != nil {
+			log.Error(readErr)
+			scrapeError = 1.0
+		} else {
+			var f interface{}
+			if jsonErr := json.Unmarshal(data, &f); jsonErr != nil {
+				log.Error(jsonErr)
+				scrapeError = 1.0
+			} else if m, ok := f.(map[string]interface{}); ok {
+				beans, _ = m["beans"].([]interface{})
+			} else {
+				scrapeError = 1.0
+			}
+		}
+	}
+
+	for _, beanData := range beans {
+		if beanMap, ok := beanData.(map[string]interface{}); ok {
+			if name, ok := beanMap["name"].(string); ok {
+				ch <- prometheus.MustNewConstMetric(scrapeSuccessDesc, prometheus.GaugeValue, 1, name)
+			}
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(start).Seconds())
+	ch <- prometheus.MustNewConstMetric(lastScrapeErrorDesc, prometheus.GaugeValue, scrapeError)
+
+	return beans
+}
+
+// safeFloat coerces a decoded JMX attribute value to float64. JMX/JSON
+// numbers normally decode as float64, but this also accepts json.Number and
+// numeric strings so a collector can skip a field instead of panicking when
+// a bean's shape drifts between Hadoop versions.
+func safeFloat(v interface{}) (float64, bool) {
+	switch t := v.(type) {
+	case float64:
+		return t, true
+	case json.Number:
+		f, err := t.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(t, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,33 @@
+package collector
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSafeFloat(t *testing.T) {
+	cases := []struct {
+		name   string
+		in     interface{}
+		want   float64
+		wantOK bool
+	}{
+		{"float64", 42.5, 42.5, true},
+		{"json.Number", json.Number("13"), 13, true},
+		{"numeric string", "7.25", 7.25, true},
+		{"non-numeric string", "active", 0, false},
+		{"bool", true, 0, false},
+		{"nil", nil, 0, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, ok := safeFloat(c.in)
+			if ok != c.wantOK {
+				t.Fatalf("safeFloat(%#v) ok = %v, want %v", c.in, ok, c.wantOK)
+			}
+			if ok && got != c.want {
+				t.Fatalf("safeFloat(%#v) = %v, want %v", c.in, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package collector
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const datanodeNamespace = "datanode"
+
+func init() {
+	RegisterCollector("datanode", true, NewDataNodeExporter)
+}
+
+// DataNodeExporter scrapes a single DataNode's JMX endpoint.
+type DataNodeExporter struct {
+	url string
+
+	capacity         typedDesc
+	dfsUsed          typedDesc
+	remaining        typedDesc
+	numFailedVolumes typedDesc
+
+	bytesWrittenTotal  typedDesc
+	bytesReadTotal     typedDesc
+	blocksWrittenTotal typedDesc
+	blocksReadTotal    typedDesc
+	heartbeatsTotal    typedDesc
+}
+
+// NewDataNodeExporter returns a prometheus.Collector that scrapes the
+// DataNode JMX endpoint at target.
+func NewDataNodeExporter(target string) prometheus.Collector {
+	counter := func(name, help string) typedDesc {
+		return typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(datanodeNamespace, "", name), help, nil, nil),
+			valueType: prometheus.CounterValue,
+		}
+	}
+	gauge := func(name, help string) typedDesc {
+		return typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(datanodeNamespace, "", name), help, nil, nil),
+			valueType: prometheus.GaugeValue,
+		}
+	}
+	return &DataNodeExporter{
+		url: target,
+
+		capacity:         gauge("Capacity", "Capacity"),
+		dfsUsed:          gauge("DfsUsed", "DfsUsed"),
+		remaining:        gauge("Remaining", "Remaining"),
+		numFailedVolumes: gauge("NumFailedVolumes", "NumFailedVolumes"),
+
+		bytesWrittenTotal:  counter("bytes_written_total", "BytesWritten"),
+		bytesReadTotal:     counter("bytes_read_total", "BytesRead"),
+		blocksWrittenTotal: counter("blocks_written_total", "BlocksWritten"),
+		blocksReadTotal:    counter("blocks_read_total", "BlocksRead"),
+		heartbeatsTotal:    counter("heartbeats_total", "HeartbeatsNumOps"),
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (e *DataNodeExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.capacity.desc
+	ch <- e.dfsUsed.desc
+	ch <- e.remaining.desc
+	ch <- e.numFailedVolumes.desc
+	ch <- e.bytesWrittenTotal.desc
+	ch <- e.bytesReadTotal.desc
+	ch <- e.blocksWrittenTotal.desc
+	ch <- e.blocksReadTotal.desc
+	ch <- e.heartbeatsTotal.desc
+	ch <- scrapeSuccessDesc
+	ch <- scrapeDurationDesc
+	ch <- lastScrapeErrorDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (e *DataNodeExporter) Collect(ch chan<- prometheus.Metric) {
+	emit := func(d typedDesc, v interface{}) {
+		if f, ok := safeFloat(v); ok {
+			ch <- d.mustNewConstMetric(f)
+		}
+	}
+
+	for _, nameData := range fetchJMXBeans(ch, e.url) {
+		nameDataMap, ok := nameData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := nameDataMap["name"].(string)
+
+		if strings.HasPrefix(name, "Hadoop:service=DataNode,name=FSDatasetState-") {
+			emit(e.capacity, nameDataMap["Capacity"])
+			emit(e.dfsUsed, nameDataMap["DfsUsed"])
+			emit(e.remaining, nameDataMap["Remaining"])
+			emit(e.numFailedVolumes, nameDataMap["NumFailedVolumes"])
+		}
+		if strings.HasPrefix(name, "Hadoop:service=DataNode,name=DataNodeActivity-") {
+			emit(e.bytesWrittenTotal, nameDataMap["BytesWritten"])
+			emit(e.bytesReadTotal, nameDataMap["BytesRead"])
+			emit(e.blocksWrittenTotal, nameDataMap["BlocksWritten"])
+			emit(e.blocksReadTotal, nameDataMap["BlocksRead"])
+			emit(e.heartbeatsTotal, nameDataMap["HeartbeatsNumOps"])
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const hbaseMasterNamespace = "hbase_master"
+
+func init() {
+	RegisterCollector("hbase-master", true, NewHBaseMasterExporter)
+}
+
+// HBaseMasterExporter scrapes a single HBase Master's JMX endpoint.
+type HBaseMasterExporter struct {
+	url string
+
+	numRegionServers     typedDesc
+	numDeadRegionServers typedDesc
+	averageLoad          typedDesc
+	clusterRequestsTotal typedDesc
+}
+
+// NewHBaseMasterExporter returns a prometheus.Collector that scrapes the
+// HBase Master JMX endpoint at target.
+func NewHBaseMasterExporter(target string) prometheus.Collector {
+	gauge := func(name, help string) typedDesc {
+		return typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(hbaseMasterNamespace, "", name), help, nil, nil),
+			valueType: prometheus.GaugeValue,
+		}
+	}
+	return &HBaseMasterExporter{
+		url: target,
+
+		numRegionServers:     gauge("numRegionServers", "numRegionServers"),
+		numDeadRegionServers: gauge("numDeadRegionServers", "numDeadRegionServers"),
+		averageLoad:          gauge("averageLoad", "averageLoad"),
+		clusterRequestsTotal: typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(hbaseMasterNamespace, "", "cluster_requests_total"), "clusterRequests", nil, nil),
+			valueType: prometheus.CounterValue,
+		},
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (e *HBaseMasterExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.numRegionServers.desc
+	ch <- e.numDeadRegionServers.desc
+	ch <- e.averageLoad.desc
+	ch <- e.clusterRequestsTotal.desc
+	ch <- scrapeSuccessDesc
+	ch <- scrapeDurationDesc
+	ch <- lastScrapeErrorDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (e *HBaseMasterExporter) Collect(ch chan<- prometheus.Metric) {
+	emit := func(d typedDesc, v interface{}) {
+		if f, ok := safeFloat(v); ok {
+			ch <- d.mustNewConstMetric(f)
+		}
+	}
+
+	for _, nameData := range fetchJMXBeans(ch, e.url) {
+		nameDataMap, ok := nameData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if nameDataMap["name"] == "Hadoop:service=HBase,name=Master,sub=Server" {
+			emit(e.numRegionServers, nameDataMap["numRegionServers"])
+			emit(e.numDeadRegionServers, nameDataMap["numDeadRegionServers"])
+			emit(e.averageLoad, nameDataMap["averageLoad"])
+			emit(e.clusterRequestsTotal, nameDataMap["clusterRequests"])
+		}
+	}
+}
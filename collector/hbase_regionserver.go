@@ -0,0 +1,94 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const hbaseRegionServerNamespace = "hbase_regionserver"
+
+func init() {
+	RegisterCollector("hbase-regionserver", true, NewHBaseRegionServerExporter)
+}
+
+// HBaseRegionServerExporter scrapes a single HBase RegionServer's JMX
+// endpoint.
+type HBaseRegionServerExporter struct {
+	url string
+
+	regionCount            typedDesc
+	storeFileCount         typedDesc
+	storeFileSize          typedDesc
+	memstoreSize           typedDesc
+	totalRequestCountTotal typedDesc
+	readRequestCountTotal  typedDesc
+	writeRequestCountTotal typedDesc
+}
+
+// NewHBaseRegionServerExporter returns a prometheus.Collector that scrapes
+// the HBase RegionServer JMX endpoint at target.
+func NewHBaseRegionServerExporter(target string) prometheus.Collector {
+	gauge := func(name, help string) typedDesc {
+		return typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(hbaseRegionServerNamespace, "", name), help, nil, nil),
+			valueType: prometheus.GaugeValue,
+		}
+	}
+	counter := func(name, help string) typedDesc {
+		return typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(hbaseRegionServerNamespace, "", name), help, nil, nil),
+			valueType: prometheus.CounterValue,
+		}
+	}
+	return &HBaseRegionServerExporter{
+		url: target,
+
+		regionCount:    gauge("regionCount", "regionCount"),
+		storeFileCount: gauge("storeFileCount", "storeFileCount"),
+		storeFileSize:  gauge("storeFileSize", "storeFileSize"),
+		memstoreSize:   gauge("memstoreSize", "memstoreSize"),
+
+		totalRequestCountTotal: counter("total_request_count_total", "totalRequestCount"),
+		readRequestCountTotal:  counter("read_request_count_total", "readRequestCount"),
+		writeRequestCountTotal: counter("write_request_count_total", "writeRequestCount"),
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (e *HBaseRegionServerExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.regionCount.desc
+	ch <- e.storeFileCount.desc
+	ch <- e.storeFileSize.desc
+	ch <- e.memstoreSize.desc
+	ch <- e.totalRequestCountTotal.desc
+	ch <- e.readRequestCountTotal.desc
+	ch <- e.writeRequestCountTotal.desc
+	ch <- scrapeSuccessDesc
+	ch <- scrapeDurationDesc
+	ch <- lastScrapeErrorDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (e *HBaseRegionServerExporter) Collect(ch chan<- prometheus.Metric) {
+	emit := func(d typedDesc, v interface{}) {
+		if f, ok := safeFloat(v); ok {
+			ch <- d.mustNewConstMetric(f)
+		}
+	}
+
+	for _, nameData := range fetchJMXBeans(ch, e.url) {
+		nameDataMap, ok := nameData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if nameDataMap["name"] == "Hadoop:service=HBase,name=RegionServer,sub=Server" {
+			emit(e.regionCount, nameDataMap["regionCount"])
+			emit(e.storeFileCount, nameDataMap["storeFileCount"])
+			emit(e.storeFileSize, nameDataMap["storeFileSize"])
+			emit(e.memstoreSize, nameDataMap["memstoreSize"])
+			emit(e.totalRequestCountTotal, nameDataMap["totalRequestCount"])
+			emit(e.readRequestCountTotal, nameDataMap["readRequestCount"])
+			emit(e.writeRequestCountTotal, nameDataMap["writeRequestCount"])
+		}
+	}
+}
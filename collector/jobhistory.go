@@ -0,0 +1,74 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const jobhistoryNamespace = "jobhistory"
+
+func init() {
+	RegisterCollector("jobhistory", true, NewJobHistoryExporter)
+}
+
+// JobHistoryExporter scrapes a single JobHistoryServer's JMX endpoint.
+type JobHistoryExporter struct {
+	url string
+
+	submittedJobsTotal typedDesc
+	completedJobsTotal typedDesc
+	failedJobsTotal    typedDesc
+	killedJobsTotal    typedDesc
+}
+
+// NewJobHistoryExporter returns a prometheus.Collector that scrapes the
+// JobHistoryServer JMX endpoint at target.
+func NewJobHistoryExporter(target string) prometheus.Collector {
+	counter := func(name, help string) typedDesc {
+		return typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(jobhistoryNamespace, "", name), help, nil, nil),
+			valueType: prometheus.CounterValue,
+		}
+	}
+	return &JobHistoryExporter{
+		url: target,
+
+		submittedJobsTotal: counter("submitted_jobs_total", "SubmittedJobs"),
+		completedJobsTotal: counter("completed_jobs_total", "CompletedJobs"),
+		failedJobsTotal:    counter("failed_jobs_total", "FailedJobs"),
+		killedJobsTotal:    counter("killed_jobs_total", "KilledJobs"),
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (e *JobHistoryExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.submittedJobsTotal.desc
+	ch <- e.completedJobsTotal.desc
+	ch <- e.failedJobsTotal.desc
+	ch <- e.killedJobsTotal.desc
+	ch <- scrapeSuccessDesc
+	ch <- scrapeDurationDesc
+	ch <- lastScrapeErrorDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (e *JobHistoryExporter) Collect(ch chan<- prometheus.Metric) {
+	emit := func(d typedDesc, v interface{}) {
+		if f, ok := safeFloat(v); ok {
+			ch <- d.mustNewConstMetric(f)
+		}
+	}
+
+	for _, nameData := range fetchJMXBeans(ch, e.url) {
+		nameDataMap, ok := nameData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if nameDataMap["name"] == "Hadoop:service=JobHistoryServer,name=JobHistoryServer" {
+			emit(e.submittedJobsTotal, nameDataMap["SubmittedJobs"])
+			emit(e.completedJobsTotal, nameDataMap["CompletedJobs"])
+			emit(e.failedJobsTotal, nameDataMap["FailedJobs"])
+			emit(e.killedJobsTotal, nameDataMap["KilledJobs"])
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package collector
+
+import (
+	"flag"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namenodeNamespace = "namenode"
+
+var namenodeLegacyMetricNames = flag.Bool("collector.namenode.legacy-metric-names", false, "Also expose the deprecated pre-1.0 gauge names for counters that were converted to CounterValue (ParNew_CollectionCount, etc). Will be removed in a future release.")
+
+func init() {
+	RegisterCollector("namenode", true, NewNameNodeExporter)
+}
+
+// NameNodeExporter scrapes a single NameNode's JMX endpoint and turns the
+// beans we care about into Prometheus metrics.
+type NameNodeExporter struct {
+	url string
+
+	missingBlocks                   typedDesc
+	underReplicatedBlocks           typedDesc
+	capacityTotal                   typedDesc
+	capacityUsed                    typedDesc
+	capacityRemaining               typedDesc
+	capacityUsedNonDFS              typedDesc
+	blocksTotal                     typedDesc
+	filesTotal                      typedDesc
+	corruptBlocks                   typedDesc
+	excessBlocks                    typedDesc
+	staleDataNodes                  typedDesc
+	pendingReplicationBlocks        typedDesc
+	pendingDeletionBlocks           typedDesc
+	scheduledReplicationBlocks      typedDesc
+	postponedMisreplicatedBlocks    typedDesc
+	expiredHeartbeats               typedDesc
+	totalLoad                       typedDesc
+	transactionsSinceLastCheckpoint typedDesc
+	transactionsSinceLastLogRoll    typedDesc
+	lastCheckpointTime              typedDesc
+	snapshots                       typedDesc
+	blockCapacity                   typedDesc
+	isActive                        typedDesc
+	totalFilesTotal                 typedDesc
+	totalSyncCountTotal             typedDesc
+
+	gcCountTotal typedDesc
+	gcTimeTotal  typedDesc
+
+	// legacyPnGcCount, etc. reproduce the pre-1.0 gauge metrics for
+	// ParNew/ConcurrentMarkSweep so dashboards built against them keep
+	// working during the deprecation window. Only emitted when
+	// --collector.namenode.legacy-metric-names is set.
+	legacyPnGcCount  typedDesc
+	legacyPnGcTime   typedDesc
+	legacyCmsGcCount typedDesc
+	legacyCmsGcTime  typedDesc
+
+	heapMemoryUsageCommitted typedDesc
+	heapMemoryUsageInit      typedDesc
+	heapMemoryUsageMax       typedDesc
+	heapMemoryUsageUsed      typedDesc
+
+	memoryPoolBytes typedDesc
+
+	state                typedDesc
+	lastHATransitionTime typedDesc
+}
+
+func newFSNamesystemDesc(name, help string) typedDesc {
+	return typedDesc{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namenodeNamespace, "", name),
+			help,
+			[]string{"ha_state", "host", "nameservice"},
+			nil,
+		),
+		valueType: prometheus.GaugeValue,
+	}
+}
+
+func newFSNamesystemCounterDesc(name, help string) typedDesc {
+	return typedDesc{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namenodeNamespace, "", name),
+			help,
+			[]string{"ha_state", "host", "nameservice"},
+			nil,
+		),
+		valueType: prometheus.CounterValue,
+	}
+}
+
+// NewNameNodeExporter returns a prometheus.Collector that scrapes the
+// NameNode JMX endpoint at target.
+func NewNameNodeExporter(target string) prometheus.Collector {
+	return &NameNodeExporter{
+		url: target,
+
+		missingBlocks:                   newFSNamesystemDesc("MissingBlocks", "MissingBlocks"),
+		underReplicatedBlocks:           newFSNamesystemDesc("UnderReplicatedBlocks", "UnderReplicatedBlocks"),
+		capacityTotal:                   newFSNamesystemDesc("CapacityTotal", "CapacityTotal"),
+		capacityUsed:                    newFSNamesystemDesc("CapacityUsed", "CapacityUsed"),
+		capacityRemaining:               newFSNamesystemDesc("CapacityRemaining", "CapacityRemaining"),
+		capacityUsedNonDFS:              newFSNamesystemDesc("CapacityUsedNonDFS", "CapacityUsedNonDFS"),
+		blocksTotal:                     newFSNamesystemDesc("BlocksTotal", "BlocksTotal"),
+		filesTotal:                      newFSNamesystemDesc("FilesTotal", "FilesTotal"),
+		corruptBlocks:                   newFSNamesystemDesc("CorruptBlocks", "CorruptBlocks"),
+		excessBlocks:                    newFSNamesystemDesc("ExcessBlocks", "ExcessBlocks"),
+		staleDataNodes:                  newFSNamesystemDesc("StaleDataNodes", "StaleDataNodes"),
+		pendingReplicationBlocks:        newFSNamesystemDesc("PendingReplicationBlocks", "PendingReplicationBlocks"),
+		pendingDeletionBlocks:           newFSNamesystemDesc("PendingDeletionBlocks", "PendingDeletionBlocks"),
+		scheduledReplicationBlocks:      newFSNamesystemDesc("ScheduledReplicationBlocks", "ScheduledReplicationBlocks"),
+		postponedMisreplicatedBlocks:    newFSNamesystemDesc("PostponedMisreplicatedBlocks", "PostponedMisreplicatedBlocks"),
+		expiredHeartbeats:               newFSNamesystemDesc("ExpiredHeartbeats", "ExpiredHeartbeats"),
+		totalLoad:                       newFSNamesystemDesc("TotalLoad", "TotalLoad"),
+		transactionsSinceLastCheckpoint: newFSNamesystemDesc("TransactionsSinceLastCheckpoint", "TransactionsSinceLastCheckpoint"),
+		transactionsSinceLastLogRoll:    newFSNamesystemDesc("TransactionsSinceLastLogRoll", "TransactionsSinceLastLogRoll"),
+		lastCheckpointTime:              newFSNamesystemDesc("LastCheckpointTime", "LastCheckpointTime, in epoch milliseconds"),
+		snapshots:                       newFSNamesystemDesc("Snapshots", "Snapshots"),
+		blockCapacity:                   newFSNamesystemDesc("BlockCapacity", "BlockCapacity"),
+		isActive: typedDesc{
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namenodeNamespace, "", "isActive"),
+				"1 if this NameNode's FSNamesystem reports HAState active, 0 otherwise",
+				[]string{"ha_state", "host", "nameservice"},
+				nil,
+			),
+			valueType: prometheus.GaugeValue,
+		},
+		totalFilesTotal:     newFSNamesystemCounterDesc("TotalFiles_total", "TotalFiles, monotonically increasing"),
+		totalSyncCountTotal: newFSNamesystemCounterDesc("TotalSyncCount_total", "TotalSyncCount, monotonically increasing"),
+
+		gcCountTotal: typedDesc{
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namenodeNamespace, "", "gc_collection_count_total"),
+				"GC collection count",
+				[]string{"gc_name"},
+				nil,
+			),
+			valueType: prometheus.CounterValue,
+		},
+		gcTimeTotal: typedDesc{
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namenodeNamespace, "", "gc_collection_time_seconds_total"),
+				"GC collection time",
+				[]string{"gc_name"},
+				nil,
+			),
+			valueType: prometheus.CounterValue,
+		},
+
+		legacyPnGcCount: typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(namenodeNamespace, "", "ParNew_CollectionCount"), "Deprecated, use namenode_gc_collection_count_total{gc_name=\"ParNew\"}. ParNew GC Count", nil, nil),
+			valueType: prometheus.GaugeValue,
+		},
+		legacyPnGcTime: typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(namenodeNamespace, "", "ParNew_CollectionTime"), "Deprecated, use namenode_gc_collection_time_seconds_total{gc_name=\"ParNew\"}. ParNew GC Time", nil, nil),
+			valueType: prometheus.GaugeValue,
+		},
+		legacyCmsGcCount: typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(namenodeNamespace, "", "ConcurrentMarkSweep_CollectionCount"), "Deprecated, use namenode_gc_collection_count_total{gc_name=\"ConcurrentMarkSweep\"}. ConcurrentMarkSweep GC Count", nil, nil),
+			valueType: prometheus.GaugeValue,
+		},
+		legacyCmsGcTime: typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(namenodeNamespace, "", "ConcurrentMarkSweep_CollectionTime"), "Deprecated, use namenode_gc_collection_time_seconds_total{gc_name=\"ConcurrentMarkSweep\"}. ConcurrentMarkSweep GC Time", nil, nil),
+			valueType: prometheus.GaugeValue,
+		},
+
+		heapMemoryUsageCommitted: typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(namenodeNamespace, "", "heapMemoryUsageCommitted"), "heapMemoryUsageCommitted", nil, nil),
+			valueType: prometheus.GaugeValue,
+		},
+		heapMemoryUsageInit: typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(namenodeNamespace, "", "heapMemoryUsageInit"), "heapMemoryUsageInit", nil, nil),
+			valueType: prometheus.GaugeValue,
+		},
+		heapMemoryUsageMax: typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(namenodeNamespace, "", "heapMemoryUsageMax"), "heapMemoryUsageMax", nil, nil),
+			valueType: prometheus.GaugeValue,
+		},
+		heapMemoryUsageUsed: typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(namenodeNamespace, "", "heapMemoryUsageUsed"), "heapMemoryUsageUsed", nil, nil),
+			valueType: prometheus.GaugeValue,
+		},
+
+		memoryPoolBytes: typedDesc{
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namenodeNamespace, "", "memory_pool_bytes"),
+				"JVM memory pool usage, in bytes",
+				[]string{"mempool", "usage"},
+				nil,
+			),
+			valueType: prometheus.GaugeValue,
+		},
+
+		state: typedDesc{
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namenodeNamespace, "", "state"),
+				"Current namenode state, 1 if active 0 if standby",
+				[]string{"nn_role", "host"},
+				nil,
+			),
+			valueType: prometheus.GaugeValue,
+		},
+		lastHATransitionTime: typedDesc{
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namenodeNamespace, "", "lastHATransitionTime"),
+				"last HA Transition Time, in epoch milliseconds",
+				[]string{"nn_role", "host"},
+				nil,
+			),
+			valueType: prometheus.GaugeValue,
+		},
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (e *NameNodeExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.missingBlocks.desc
+	ch <- e.underReplicatedBlocks.desc
+	ch <- e.capacityTotal.desc
+	ch <- e.capacityUsed.desc
+	ch <- e.capacityRemaining.desc
+	ch <- e.capacityUsedNonDFS.desc
+	ch <- e.blocksTotal.desc
+	ch <- e.filesTotal.desc
+	ch <- e.corruptBlocks.desc
+	ch <- e.excessBlocks.desc
+	ch <- e.staleDataNodes.desc
+	ch <- e.pendingReplicationBlocks.desc
+	ch <- e.pendingDeletionBlocks.desc
+	ch <- e.scheduledReplicationBlocks.desc
+	ch <- e.postponedMisreplicatedBlocks.desc
+	ch <- e.expiredHeartbeats.desc
+	ch <- e.totalLoad.desc
+	ch <- e.transactionsSinceLastCheckpoint.desc
+	ch <- e.transactionsSinceLastLogRoll.desc
+	ch <- e.lastCheckpointTime.desc
+	ch <- e.snapshots.desc
+	ch <- e.blockCapacity.desc
+	ch <- e.isActive.desc
+	ch <- e.totalFilesTotal.desc
+	ch <- e.totalSyncCountTotal.desc
+	ch <- e.gcCountTotal.desc
+	ch <- e.gcTimeTotal.desc
+	if *namenodeLegacyMetricNames {
+		ch <- e.legacyPnGcCount.desc
+		ch <- e.legacyPnGcTime.desc
+		ch <- e.legacyCmsGcCount.desc
+		ch <- e.legacyCmsGcTime.desc
+	}
+	ch <- e.heapMemoryUsageCommitted.desc
+	ch <- e.heapMemoryUsageInit.desc
+	ch <- e.heapMemoryUsageMax.desc
+	ch <- e.heapMemoryUsageUsed.desc
+	ch <- e.memoryPoolBytes.desc
+	ch <- e.state.desc
+	ch <- e.lastHATransitionTime.desc
+	ch <- scrapeSuccessDesc
+	ch <- scrapeDurationDesc
+	ch <- lastScrapeErrorDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (e *NameNodeExporter) Collect(ch chan<- prometheus.Metric) {
+	emit := func(d typedDesc, v interface{}, labelValues ...string) {
+		if f, ok := safeFloat(v); ok {
+			ch <- d.mustNewConstMetric(f, labelValues...)
+		}
+	}
+
+	for _, nameData := range fetchJMXBeans(ch, e.url) {
+		nameDataMap, ok := nameData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if nameDataMap["name"] == "Hadoop:service=NameNode,name=FSNamesystem" {
+			haState, _ := nameDataMap["tag.HAState"].(string)
+			host, _ := nameDataMap["tag.Hostname"].(string)
+			nameservice, _ := nameDataMap["tag.Nameservice"].(string)
+
+			emit(e.missingBlocks, nameDataMap["MissingBlocks"], haState, host, nameservice)
+			emit(e.underReplicatedBlocks, nameDataMap["UnderReplicatedBlocks"], haState, host, nameservice)
+			emit(e.capacityTotal, nameDataMap["CapacityTotal"], haState, host, nameservice)
+			emit(e.capacityUsed, nameDataMap["CapacityUsed"], haState, host, nameservice)
+			emit(e.capacityRemaining, nameDataMap["CapacityRemaining"], haState, host, nameservice)
+			emit(e.capacityUsedNonDFS, nameDataMap["CapacityUsedNonDFS"], haState, host, nameservice)
+			emit(e.blocksTotal, nameDataMap["BlocksTotal"], haState, host, nameservice)
+			emit(e.filesTotal, nameDataMap["FilesTotal"], haState, host, nameservice)
+			emit(e.corruptBlocks, nameDataMap["CorruptBlocks"], haState, host, nameservice)
+			emit(e.excessBlocks, nameDataMap["ExcessBlocks"], haState, host, nameservice)
+			emit(e.staleDataNodes, nameDataMap["StaleDataNodes"], haState, host, nameservice)
+			emit(e.pendingReplicationBlocks, nameDataMap["PendingReplicationBlocks"], haState, host, nameservice)
+			emit(e.pendingDeletionBlocks, nameDataMap["PendingDeletionBlocks"], haState, host, nameservice)
+			emit(e.scheduledReplicationBlocks, nameDataMap["ScheduledReplicationBlocks"], haState, host, nameservice)
+			emit(e.postponedMisreplicatedBlocks, nameDataMap["PostponedMisreplicatedBlocks"], haState, host, nameservice)
+			emit(e.expiredHeartbeats, nameDataMap["ExpiredHeartbeats"], haState, host, nameservice)
+			emit(e.totalLoad, nameDataMap["TotalLoad"], haState, host, nameservice)
+			emit(e.transactionsSinceLastCheckpoint, nameDataMap["TransactionsSinceLastCheckpoint"], haState, host, nameservice)
+			emit(e.transactionsSinceLastLogRoll, nameDataMap["TransactionsSinceLastLogRoll"], haState, host, nameservice)
+			emit(e.lastCheckpointTime, nameDataMap["LastCheckpointTime"], haState, host, nameservice)
+			emit(e.snapshots, nameDataMap["Snapshots"], haState, host, nameservice)
+			emit(e.blockCapacity, nameDataMap["BlockCapacity"], haState, host, nameservice)
+			emit(e.totalFilesTotal, nameDataMap["TotalFiles"], haState, host, nameservice)
+			emit(e.totalSyncCountTotal, nameDataMap["TotalSyncCount"], haState, host, nameservice)
+
+			isActive := 0.0
+			if haState == "active" {
+				isActive = 1.0
+			}
+			emit(e.isActive, isActive, haState, host, nameservice)
+		}
+		if nameDataMap["name"] == "Hadoop:service=NameNode,name=NameNodeStatus" {
+			nnRole, _ := nameDataMap["NNRole"].(string)
+			hostAndPort, _ := nameDataMap["HostAndPort"].(string)
+
+			state := 0.0
+			if nameDataMap["State"] == "active" {
+				state = 1.0
+			}
+			emit(e.state, state, nnRole, hostAndPort)
+			emit(e.lastHATransitionTime, nameDataMap["LastHATransitionTime"], nnRole, hostAndPort)
+		}
+		if name, _ := nameDataMap["name"].(string); len(name) > len("java.lang:type=GarbageCollector,name=") &&
+			name[:len("java.lang:type=GarbageCollector,name=")] == "java.lang:type=GarbageCollector,name=" {
+			gcName := name[len("java.lang:type=GarbageCollector,name="):]
+			collectionCount, hasCount := safeFloat(nameDataMap["CollectionCount"])
+			collectionTimeMs, hasTime := safeFloat(nameDataMap["CollectionTime"])
+
+			if hasCount {
+				ch <- e.gcCountTotal.mustNewConstMetric(collectionCount, gcName)
+			}
+			if hasTime {
+				ch <- e.gcTimeTotal.mustNewConstMetric(collectionTimeMs/1000, gcName)
+			}
+
+			if *namenodeLegacyMetricNames {
+				switch gcName {
+				case "ParNew":
+					if hasCount {
+						ch <- e.legacyPnGcCount.mustNewConstMetric(collectionCount)
+					}
+					if hasTime {
+						ch <- e.legacyPnGcTime.mustNewConstMetric(collectionTimeMs)
+					}
+				case "ConcurrentMarkSweep":
+					if hasCount {
+						ch <- e.legacyCmsGcCount.mustNewConstMetric(collectionCount)
+					}
+					if hasTime {
+						ch <- e.legacyCmsGcTime.mustNewConstMetric(collectionTimeMs)
+					}
+				}
+			}
+		}
+		if nameDataMap["name"] == "java.lang:type=Memory" {
+			if heapMemoryUsage, ok := nameDataMap["HeapMemoryUsage"].(map[string]interface{}); ok {
+				emit(e.heapMemoryUsageCommitted, heapMemoryUsage["committed"])
+				emit(e.heapMemoryUsageInit, heapMemoryUsage["init"])
+				emit(e.heapMemoryUsageMax, heapMemoryUsage["max"])
+				emit(e.heapMemoryUsageUsed, heapMemoryUsage["used"])
+			}
+		}
+		if name, _ := nameDataMap["name"].(string); len(name) > len("java.lang:type=MemoryPool,name=") &&
+			name[:len("java.lang:type=MemoryPool,name=")] == "java.lang:type=MemoryPool,name=" {
+			mempool := name[len("java.lang:type=MemoryPool,name="):]
+			if usage, ok := nameDataMap["Usage"].(map[string]interface{}); ok {
+				emit(e.memoryPoolBytes, usage["committed"], mempool, "committed")
+				emit(e.memoryPoolBytes, usage["init"], mempool, "init")
+				emit(e.memoryPoolBytes, usage["max"], mempool, "max")
+				emit(e.memoryPoolBytes, usage["used"], mempool, "used")
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const nodemanagerNamespace = "nodemanager"
+
+func init() {
+	RegisterCollector("nodemanager", true, NewNodeManagerExporter)
+}
+
+// NodeManagerExporter scrapes a single NodeManager's JMX endpoint.
+type NodeManagerExporter struct {
+	url string
+
+	containersLaunchedTotal  typedDesc
+	containersCompletedTotal typedDesc
+	containersFailedTotal    typedDesc
+	containersKilledTotal    typedDesc
+	containersRunning        typedDesc
+	allocatedGB              typedDesc
+	availableGB              typedDesc
+	allocatedVCores          typedDesc
+	availableVCores          typedDesc
+}
+
+// NewNodeManagerExporter returns a prometheus.Collector that scrapes the
+// NodeManager JMX endpoint at target.
+func NewNodeManagerExporter(target string) prometheus.Collector {
+	counter := func(name, help string) typedDesc {
+		return typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(nodemanagerNamespace, "", name), help, nil, nil),
+			valueType: prometheus.CounterValue,
+		}
+	}
+	gauge := func(name, help string) typedDesc {
+		return typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(nodemanagerNamespace, "", name), help, nil, nil),
+			valueType: prometheus.GaugeValue,
+		}
+	}
+	return &NodeManagerExporter{
+		url: target,
+
+		containersLaunchedTotal:  counter("containers_launched_total", "ContainersLaunched"),
+		containersCompletedTotal: counter("containers_completed_total", "ContainersCompleted"),
+		containersFailedTotal:    counter("containers_failed_total", "ContainersFailed"),
+		containersKilledTotal:    counter("containers_killed_total", "ContainersKilled"),
+		containersRunning:        gauge("ContainersRunning", "ContainersRunning"),
+		allocatedGB:              gauge("AllocatedGB", "AllocatedGB"),
+		availableGB:              gauge("AvailableGB", "AvailableGB"),
+		allocatedVCores:          gauge("AllocatedVCores", "AllocatedVCores"),
+		availableVCores:          gauge("AvailableVCores", "AvailableVCores"),
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (e *NodeManagerExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.containersLaunchedTotal.desc
+	ch <- e.containersCompletedTotal.desc
+	ch <- e.containersFailedTotal.desc
+	ch <- e.containersKilledTotal.desc
+	ch <- e.containersRunning.desc
+	ch <- e.allocatedGB.desc
+	ch <- e.availableGB.desc
+	ch <- e.allocatedVCores.desc
+	ch <- e.availableVCores.desc
+	ch <- scrapeSuccessDesc
+	ch <- scrapeDurationDesc
+	ch <- lastScrapeErrorDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (e *NodeManagerExporter) Collect(ch chan<- prometheus.Metric) {
+	emit := func(d typedDesc, v interface{}) {
+		if f, ok := safeFloat(v); ok {
+			ch <- d.mustNewConstMetric(f)
+		}
+	}
+
+	for _, nameData := range fetchJMXBeans(ch, e.url) {
+		nameDataMap, ok := nameData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if nameDataMap["name"] == "Hadoop:service=NodeManager,name=NodeManagerMetrics" {
+			emit(e.containersLaunchedTotal, nameDataMap["ContainersLaunched"])
+			emit(e.containersCompletedTotal, nameDataMap["ContainersCompleted"])
+			emit(e.containersFailedTotal, nameDataMap["ContainersFailed"])
+			emit(e.containersKilledTotal, nameDataMap["ContainersKilled"])
+			emit(e.containersRunning, nameDataMap["ContainersRunning"])
+			emit(e.allocatedGB, nameDataMap["AllocatedGB"])
+			emit(e.availableGB, nameDataMap["AvailableGB"])
+			emit(e.allocatedVCores, nameDataMap["AllocatedVCores"])
+			emit(e.availableVCores, nameDataMap["AvailableVCores"])
+		}
+	}
+}
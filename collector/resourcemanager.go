@@ -0,0 +1,114 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const resourcemanagerNamespace = "resourcemanager"
+
+func init() {
+	RegisterCollector("resourcemanager", true, NewResourceManagerExporter)
+}
+
+// ResourceManagerExporter scrapes a single ResourceManager's JMX endpoint.
+type ResourceManagerExporter struct {
+	url string
+
+	activeNMs         typedDesc
+	decommissionedNMs typedDesc
+	lostNMs           typedDesc
+	unhealthyNMs      typedDesc
+	rebootedNMs       typedDesc
+
+	appsSubmitted typedDesc
+	appsRunning   typedDesc
+	appsPending   typedDesc
+	appsCompleted typedDesc
+	appsFailed    typedDesc
+	appsKilled    typedDesc
+	availableMB   typedDesc
+	allocatedMB   typedDesc
+}
+
+// NewResourceManagerExporter returns a prometheus.Collector that scrapes the
+// ResourceManager JMX endpoint at target.
+func NewResourceManagerExporter(target string) prometheus.Collector {
+	gauge := func(name, help string) typedDesc {
+		return typedDesc{
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(resourcemanagerNamespace, "", name), help, nil, nil),
+			valueType: prometheus.GaugeValue,
+		}
+	}
+	return &ResourceManagerExporter{
+		url: target,
+
+		activeNMs:         gauge("NumActiveNMs", "NumActiveNMs"),
+		decommissionedNMs: gauge("NumDecommissionedNMs", "NumDecommissionedNMs"),
+		lostNMs:           gauge("NumLostNMs", "NumLostNMs"),
+		unhealthyNMs:      gauge("NumUnhealthyNMs", "NumUnhealthyNMs"),
+		rebootedNMs:       gauge("NumRebootedNMs", "NumRebootedNMs"),
+
+		appsSubmitted: gauge("AppsSubmitted", "AppsSubmitted"),
+		appsRunning:   gauge("AppsRunning", "AppsRunning"),
+		appsPending:   gauge("AppsPending", "AppsPending"),
+		appsCompleted: gauge("AppsCompleted", "AppsCompleted"),
+		appsFailed:    gauge("AppsFailed", "AppsFailed"),
+		appsKilled:    gauge("AppsKilled", "AppsKilled"),
+		availableMB:   gauge("AvailableMB", "AvailableMB"),
+		allocatedMB:   gauge("AllocatedMB", "AllocatedMB"),
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (e *ResourceManagerExporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.activeNMs.desc
+	ch <- e.decommissionedNMs.desc
+	ch <- e.lostNMs.desc
+	ch <- e.unhealthyNMs.desc
+	ch <- e.rebootedNMs.desc
+	ch <- e.appsSubmitted.desc
+	ch <- e.appsRunning.desc
+	ch <- e.appsPending.desc
+	ch <- e.appsCompleted.desc
+	ch <- e.appsFailed.desc
+	ch <- e.appsKilled.desc
+	ch <- e.availableMB.desc
+	ch <- e.allocatedMB.desc
+	ch <- scrapeSuccessDesc
+	ch <- scrapeDurationDesc
+	ch <- lastScrapeErrorDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (e *ResourceManagerExporter) Collect(ch chan<- prometheus.Metric) {
+	emit := func(d typedDesc, v interface{}) {
+		if f, ok := safeFloat(v); ok {
+			ch <- d.mustNewConstMetric(f)
+		}
+	}
+
+	for _, nameData := range fetchJMXBeans(ch, e.url) {
+		nameDataMap, ok := nameData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if nameDataMap["name"] == "Hadoop:service=ResourceManager,name=ClusterMetrics" {
+			emit(e.activeNMs, nameDataMap["NumActiveNMs"])
+			emit(e.decommissionedNMs, nameDataMap["NumDecommissionedNMs"])
+			emit(e.lostNMs, nameDataMap["NumLostNMs"])
+			emit(e.unhealthyNMs, nameDataMap["NumUnhealthyNMs"])
+			emit(e.rebootedNMs, nameDataMap["NumRebootedNMs"])
+		}
+		if nameDataMap["name"] == "Hadoop:service=ResourceManager,name=QueueMetrics,q0=root" {
+			emit(e.appsSubmitted, nameDataMap["AppsSubmitted"])
+			emit(e.appsRunning, nameDataMap["AppsRunning"])
+			emit(e.appsPending, nameDataMap["AppsPending"])
+			emit(e.appsCompleted, nameDataMap["AppsCompleted"])
+			emit(e.appsFailed, nameDataMap["AppsFailed"])
+			emit(e.appsKilled, nameDataMap["AppsKilled"])
+			emit(e.availableMB, nameDataMap["AvailableMB"])
+			emit(e.allocatedMB, nameDataMap["AllocatedMB"])
+		}
+	}
+}
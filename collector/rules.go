@@ -0,0 +1,238 @@
+package collector
+
+import (
+	"io/ioutil"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// RuleConfig is the schema for --config.file: a role built entirely out of
+// bean_pattern/jmx_attr rules, so new JMX coverage doesn't require a Go
+// change or a rebuild. Loading one registers its role the same way an
+// init()-registered collector does.
+type RuleConfig struct {
+	Role  string     `yaml:"role"`
+	Rules []BeanRule `yaml:"rules"`
+}
+
+// BeanRule matches every JMX bean whose "name" satisfies BeanPattern (a
+// path.Match glob, e.g. "java.lang:type=GarbageCollector,name=*") and turns
+// each of its Metrics into a Prometheus series.
+type BeanRule struct {
+	BeanPattern string       `yaml:"bean_pattern"`
+	Metrics     []MetricRule `yaml:"metrics"`
+}
+
+// MetricRule describes one metric to extract from a matched bean. JMXAttr
+// may be a dotted path ("HeapMemoryUsage.used") to reach into nested JMX
+// objects. Labels map a label name to either a literal string or, prefixed
+// with "$", a field to read off the same bean (e.g. "$tag.HAState").
+type MetricRule struct {
+	JMXAttr string            `yaml:"jmx_attr"`
+	Name    string            `yaml:"name"`
+	Help    string            `yaml:"help"`
+	Type    string            `yaml:"type"` // "gauge" or "counter"
+	Unit    string            `yaml:"unit"` // "", "ms" (milliseconds -> seconds), "B" (size string -> bytes)
+	Labels  map[string]string `yaml:"labels"`
+}
+
+// LoadConfigFile parses a YAML rule file and registers the role it defines,
+// making it selectable via /probe?role=<role>, the same as any built-in
+// collector.
+func LoadConfigFile(configFile string) error {
+	data, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return err
+	}
+	var cfg RuleConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+	RegisterCollector(cfg.Role, true, func(target string) prometheus.Collector {
+		return newRuleExporter(cfg, target)
+	})
+	return nil
+}
+
+type compiledMetric struct {
+	rule       MetricRule
+	desc       *prometheus.Desc
+	valueType  prometheus.ValueType
+	labelNames []string
+}
+
+type compiledBean struct {
+	pattern string
+	metrics []compiledMetric
+}
+
+// ruleExporter is a prometheus.Collector entirely driven by a RuleConfig.
+type ruleExporter struct {
+	url   string
+	beans []compiledBean
+}
+
+func newRuleExporter(cfg RuleConfig, target string) *ruleExporter {
+	e := &ruleExporter{url: target}
+	for _, bean := range cfg.Rules {
+		cb := compiledBean{pattern: bean.BeanPattern}
+		for _, m := range bean.Metrics {
+			labelNames := make([]string, 0, len(m.Labels))
+			for k := range m.Labels {
+				labelNames = append(labelNames, k)
+			}
+			sort.Strings(labelNames)
+
+			valueType := prometheus.GaugeValue
+			if m.Type == "counter" {
+				valueType = prometheus.CounterValue
+			}
+			cb.metrics = append(cb.metrics, compiledMetric{
+				rule:       m,
+				desc:       prometheus.NewDesc(m.Name, m.Help, labelNames, nil),
+				valueType:  valueType,
+				labelNames: labelNames,
+			})
+		}
+		e.beans = append(e.beans, cb)
+	}
+	return e
+}
+
+// Describe implements the prometheus.Collector interface.
+func (e *ruleExporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, bean := range e.beans {
+		for _, m := range bean.metrics {
+			ch <- m.desc
+		}
+	}
+	ch <- scrapeSuccessDesc
+	ch <- scrapeDurationDesc
+	ch <- lastScrapeErrorDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (e *ruleExporter) Collect(ch chan<- prometheus.Metric) {
+	for _, beanData := range fetchJMXBeans(ch, e.url) {
+		beanMap, ok := beanData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := beanMap["name"].(string)
+		for _, bean := range e.beans {
+			if matched, err := path.Match(bean.pattern, name); err != nil || !matched {
+				continue
+			}
+			for _, metric := range bean.metrics {
+				raw, ok := lookupJMXAttr(beanMap, metric.rule.JMXAttr)
+				if !ok {
+					continue
+				}
+				value, ok := convertUnit(raw, metric.rule.Unit)
+				if !ok {
+					continue
+				}
+				labelValues := make([]string, len(metric.labelNames))
+				for i, labelName := range metric.labelNames {
+					labelValues[i] = resolveLabelTemplate(beanMap, metric.rule.Labels[labelName])
+				}
+				ch <- prometheus.MustNewConstMetric(metric.desc, metric.valueType, value, labelValues...)
+			}
+		}
+	}
+}
+
+// lookupJMXAttr resolves a dotted JSON-path style attribute name (e.g.
+// "HeapMemoryUsage.used") against a decoded JMX bean and returns the raw,
+// still-undecoded value so the caller can apply unit conversion.
+func lookupJMXAttr(bean map[string]interface{}, attr string) (interface{}, bool) {
+	var cur interface{} = bean
+	parts := strings.Split(attr, ".")
+	for i, part := range parts {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		next, ok := m[part]
+		if !ok {
+			return nil, false
+		}
+		cur = next
+		if i == len(parts)-1 {
+			return cur, true
+		}
+	}
+	return nil, false
+}
+
+// byteSizeSuffixes maps a case-insensitive size suffix, longest first, to
+// the number of bytes it represents.
+var byteSizeSuffixes = []struct {
+	suffix string
+	factor float64
+}{
+	{"TB", 1 << 40},
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// convertUnit coerces a raw JMX attribute value to float64 and applies a
+// MetricRule's Unit scaling: "ms" divides a numeric value by 1000, "B"
+// parses a size string (e.g. "10.5MB", already-numeric values pass through
+// unchanged) into a byte count, and "" leaves the value as-is.
+func convertUnit(raw interface{}, unit string) (float64, bool) {
+	if unit == "B" {
+		if f, ok := safeFloat(raw); ok {
+			return f, true
+		}
+		return parseByteSize(raw)
+	}
+	f, ok := safeFloat(raw)
+	if !ok {
+		return 0, false
+	}
+	if unit == "ms" {
+		f /= 1000
+	}
+	return f, true
+}
+
+// parseByteSize parses a human-readable size string such as "10.5MB" or
+// "2 GB" into a byte count.
+func parseByteSize(raw interface{}) (float64, bool) {
+	s, ok := raw.(string)
+	if !ok {
+		return 0, false
+	}
+	s = strings.TrimSpace(s)
+	for _, sz := range byteSizeSuffixes {
+		if !strings.HasSuffix(strings.ToUpper(s), sz.suffix) {
+			continue
+		}
+		numPart := strings.TrimSpace(s[:len(s)-len(sz.suffix)])
+		n, err := strconv.ParseFloat(numPart, 64)
+		if err != nil {
+			return 0, false
+		}
+		return n * sz.factor, true
+	}
+	return 0, false
+}
+
+// resolveLabelTemplate resolves a rule's label value: a leading "$" pulls
+// the named field off the same bean (e.g. "$tag.HAState"); anything else is
+// used as a literal label value.
+func resolveLabelTemplate(bean map[string]interface{}, template string) string {
+	if strings.HasPrefix(template, "$") {
+		v, _ := bean[template[1:]].(string)
+		return v
+	}
+	return template
+}
@@ -0,0 +1,88 @@
+package collector
+
+import "testing"
+
+func TestLookupJMXAttr(t *testing.T) {
+	bean := map[string]interface{}{
+		"MissingBlocks": 3.0,
+		"HeapMemoryUsage": map[string]interface{}{
+			"used": 1024.0,
+		},
+	}
+
+	cases := []struct {
+		name   string
+		attr   string
+		want   interface{}
+		wantOK bool
+	}{
+		{"top-level attr", "MissingBlocks", 3.0, true},
+		{"dotted path into nested object", "HeapMemoryUsage.used", 1024.0, true},
+		{"missing attr", "NoSuchAttr", nil, false},
+		{"missing nested attr", "HeapMemoryUsage.committed", nil, false},
+		{"dotted path through a non-object", "MissingBlocks.used", nil, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, ok := lookupJMXAttr(bean, c.attr)
+			if ok != c.wantOK {
+				t.Fatalf("lookupJMXAttr(%q) ok = %v, want %v", c.attr, ok, c.wantOK)
+			}
+			if ok && got != c.want {
+				t.Fatalf("lookupJMXAttr(%q) = %v, want %v", c.attr, got, c.want)
+			}
+		})
+	}
+}
+
+func TestConvertUnit(t *testing.T) {
+	cases := []struct {
+		name   string
+		raw    interface{}
+		unit   string
+		want   float64
+		wantOK bool
+	}{
+		{"no unit passes numeric through", 5.0, "", 5.0, true},
+		{"ms converts to seconds", 1500.0, "ms", 1.5, true},
+		{"B passes an already-numeric value through", 2048.0, "B", 2048.0, true},
+		{"B parses a size string", "10MB", "B", 10 * (1 << 20), true},
+		{"B rejects an unparseable size string", "lots", "B", 0, false},
+		{"non-numeric value with no unit", "active", "", 0, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, ok := convertUnit(c.raw, c.unit)
+			if ok != c.wantOK {
+				t.Fatalf("convertUnit(%#v, %q) ok = %v, want %v", c.raw, c.unit, ok, c.wantOK)
+			}
+			if ok && got != c.want {
+				t.Fatalf("convertUnit(%#v, %q) = %v, want %v", c.raw, c.unit, got, c.want)
+			}
+		})
+	}
+}
+
+func TestResolveLabelTemplate(t *testing.T) {
+	bean := map[string]interface{}{
+		"tag.HAState": "active",
+		"name":        "Hadoop:service=NameNode,name=FSNamesystem",
+	}
+
+	cases := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"field reference", "$tag.HAState", "active"},
+		{"literal", "namenode", "namenode"},
+		{"missing field reference", "$tag.Missing", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := resolveLabelTemplate(bean, c.template); got != c.want {
+				t.Fatalf("resolveLabelTemplate(%q) = %q, want %q", c.template, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Command hadoop_exporter serves Prometheus metrics for one or more Hadoop
+// roles (NameNode, DataNode, ResourceManager, NodeManager, JobHistoryServer,
+// HBase Master/RegionServer). Each role is a self-contained collector
+// registered under the collector package; scraping a specific cluster member
+// happens on demand via /probe?role=<role>&target=<jmx-url>, so a single
+// binary can stand in for a whole fleet.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/chicofranchico/hadoop_exporter/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/log"
+)
+
+var (
+	listenAddress = flag.String("web.listen-address", ":9070", "Address on which to expose metrics and web interface.")
+	metricsPath   = flag.String("web.telemetry-path", "/metrics", "Path under which to expose metrics.")
+	configFile    = flag.String("config.file", "", "Path to a YAML rule file (see examples/) that registers an additional, or overrides an existing, /probe role.")
+)
+
+// configFileFromArgs pulls the --config.file value out of the raw argument
+// list, the same way the real -config.file flag eventually will, but before
+// flag.Parse() has run. This lets LoadConfigFile register a config-file-only
+// role's --collector.<role> flag early enough for flag.Parse() to actually
+// recognize it on the command line, instead of it always taking its default.
+func configFileFromArgs(args []string) string {
+	for i, arg := range args {
+		arg = strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if arg == "config.file" && i+1 < len(args) {
+			return args[i+1]
+		}
+		if value := strings.TrimPrefix(arg, "config.file="); value != arg {
+			return value
+		}
+	}
+	return ""
+}
+
+// probeHandler scrapes the target passed in the "target" query parameter,
+// using the collector named by "role" (defaulting to "namenode" for
+// backwards compatibility), against a fresh registry so concurrent probes
+// never share collector state.
+func probeHandler(w http.ResponseWriter, r *http.Request) {
+	target := r.URL.Query().Get("target")
+	if target == "" {
+		http.Error(w, "target parameter is missing", http.StatusBadRequest)
+		return
+	}
+
+	role := r.URL.Query().Get("role")
+	if role == "" {
+		role = "namenode"
+	}
+
+	factory, ok := collector.Enabled()[role]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown or disabled role %q", role), http.StatusBadRequest)
+		return
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(factory(target))
+
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
+
+func main() {
+	if cf := configFileFromArgs(os.Args[1:]); cf != "" {
+		if err := collector.LoadConfigFile(cf); err != nil {
+			log.Fatalf("Error loading --config.file %q: %s", cf, err)
+		}
+	}
+
+	flag.Parse()
+
+	log.Printf("Starting Server: %s", *listenAddress)
+	http.Handle(*metricsPath, promhttp.Handler())
+	http.HandleFunc("/probe", probeHandler)
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html>
+        <head><title>Hadoop Exporter</title></head>
+        <body>
+        <h1>Hadoop Exporter</h1>
+        <p><a href="` + *metricsPath + `">Metrics</a></p>
+        <p><a href="/probe?role=namenode&target=http://localhost:50070/jmx">Probe a NameNode</a></p>
+        </body>
+        </html>`))
+	})
+	err := http.ListenAndServe(*listenAddress, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+}